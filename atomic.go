@@ -0,0 +1,94 @@
+// Copyright 2025 Nuclear Pasta. All rights reserved.
+// Use of this source code is governed by the MIT license.
+// license that can be found in the LICENSE file.
+
+package confman
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+)
+
+// atomicTmpSeq hands out a unique number per writeAtomic call in this
+// process, so concurrent atomic writes to the same file from separate
+// goroutines never pick the same temp name.
+var atomicTmpSeq uint64
+
+// SetAtomic enables or disables atomic writes for this Config.
+//
+// When enabled, [Config.Write] and its format-specific variants write to a
+// sibling temporary file, sync it to stable storage, then rename it over the
+// target. This prevents a crash or power loss mid-write from leaving behind
+// a half-written config file, at the cost of an extra rename per write.
+func (c *Config) SetAtomic(enabled bool) {
+	c.atomic = enabled
+}
+
+// writeAtomic writes to the named file through write, replacing the file's
+// contents only once write has fully succeeded. The original file's mode is
+// preserved on replace, and the temporary file is cleaned up on any error.
+func (c *Config) writeAtomic(name string, write func(io.Writer) error) error {
+	if err := c.verifyExists(name); err != nil {
+		return err
+	}
+
+	info, err := c.Stat(name)
+	if err != nil {
+		return err
+	}
+
+	target := c.child(name)
+	seq := atomic.AddUint64(&atomicTmpSeq, 1)
+	tmp := fmt.Sprintf("%s.tmp-%d-%d", target, os.Getpid(), seq)
+
+	w, err := c.fs.OpenFile(tmp, os.O_RDWR|os.O_CREATE|os.O_EXCL, info.Mode())
+	if err != nil {
+		return err
+	}
+
+	if err := write(w); err != nil {
+		w.Close()
+		c.fs.Remove(tmp)
+		return err
+	}
+
+	if s, ok := w.(interface{ Sync() error }); ok {
+		if err := s.Sync(); err != nil {
+			w.Close()
+			c.fs.Remove(tmp)
+			return err
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		c.fs.Remove(tmp)
+		return err
+	}
+
+	if err := c.fs.Rename(tmp, target); err != nil {
+		c.fs.Remove(tmp)
+		return err
+	}
+
+	syncParentDir(target)
+
+	return nil
+}
+
+// syncParentDir best-effort fsyncs the parent directory of path so the
+// rename in [Config.writeAtomic] is itself durable on POSIX systems. Failures
+// are ignored: directory fsync isn't supported on every platform, and it is
+// not worth failing an otherwise-successful write over.
+func syncParentDir(path string) {
+	dir, err := os.Open(filepath.Dir(path))
+	if err != nil {
+		return
+	}
+
+	defer dir.Close()
+
+	dir.Sync()
+}
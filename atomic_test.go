@@ -0,0 +1,122 @@
+// Copyright 2025 Nuclear Pasta. All rights reserved.
+// Use of this source code is governed by the MIT license.
+// license that can be found in the LICENSE file.
+
+package confman
+
+import (
+	"strings"
+	"sync"
+	"testing"
+)
+
+// unencodable has a channel field, which [encoding/json] always fails to
+// marshal; it stands in for "the encoder returned an error" in the tests
+// below.
+type unencodable struct {
+	C chan int
+}
+
+func tmpFileCount(fs *MemFS) int {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	n := 0
+
+	for path := range fs.files {
+		if strings.Contains(path, ".tmp-") {
+			n++
+		}
+	}
+
+	return n
+}
+
+func TestAtomicWriteJsonSuccess(t *testing.T) {
+	c := newMemConfig(t)
+	c.SetAtomic(true)
+
+	if _, err := c.Create("settings.json", []byte(`{"a":1}`), 0o644); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	if err := c.WriteJson("settings.json", map[string]int{"a": 2}); err != nil {
+		t.Fatalf("WriteJson: %v", err)
+	}
+
+	got, err := c.ReadString("settings.json")
+	if err != nil {
+		t.Fatalf("ReadString: %v", err)
+	}
+
+	if !strings.Contains(got, `"a":2`) {
+		t.Fatalf("ReadString = %q, want it to contain %q", got, `"a":2`)
+	}
+
+	if n := tmpFileCount(c.fs.(*MemFS)); n != 0 {
+		t.Fatalf("%d leftover temp files after a successful atomic write, want 0", n)
+	}
+}
+
+func TestAtomicWriteJsonRollsBackOnEncodeError(t *testing.T) {
+	c := newMemConfig(t)
+	c.SetAtomic(true)
+
+	const original = `{"a":1}`
+
+	if _, err := c.Create("settings.json", []byte(original), 0o644); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	if err := c.WriteJson("settings.json", unencodable{}); err == nil {
+		t.Fatal("WriteJson with an unencodable value returned nil error, want one")
+	}
+
+	got, err := c.ReadString("settings.json")
+	if err != nil {
+		t.Fatalf("ReadString: %v", err)
+	}
+
+	if got != original {
+		t.Fatalf("file contents = %q after a failed atomic write, want untouched original %q", got, original)
+	}
+
+	if n := tmpFileCount(c.fs.(*MemFS)); n != 0 {
+		t.Fatalf("%d leftover temp files after a failed atomic write, want 0", n)
+	}
+}
+
+func TestAtomicWriteConcurrentSameFile(t *testing.T) {
+	c := newMemConfig(t)
+	c.SetAtomic(true)
+
+	if _, err := c.Create("counter.txt", []byte("0"), 0o644); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	const writers = 16
+
+	var wg sync.WaitGroup
+	errs := make([]error, writers)
+
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = c.Write("counter.txt", []byte("x"))
+		}(i)
+	}
+
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("writer %d: concurrent atomic Write failed: %v", i, err)
+		}
+	}
+
+	if n := tmpFileCount(c.fs.(*MemFS)); n != 0 {
+		t.Fatalf("%d leftover temp files after concurrent atomic writes, want 0", n)
+	}
+}
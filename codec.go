@@ -0,0 +1,156 @@
+// Copyright 2025 Nuclear Pasta. All rights reserved.
+// Use of this source code is governed by the MIT license.
+// license that can be found in the LICENSE file.
+
+package confman
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sync"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Codec encodes and decodes values to and from a particular file format,
+// letting [Config.Marshal] and [Config.Unmarshal] support a format without
+// Config importing its dependencies directly.
+type Codec interface {
+	// Name returns the codec's name, e.g. "json" or "yaml".
+	Name() string
+	// Encode writes v to w in this codec's format.
+	Encode(w io.Writer, v any) error
+	// Decode reads r in this codec's format into the value pointed to by v.
+	Decode(r io.Reader, v any) error
+}
+
+var (
+	codecsMu sync.RWMutex
+	codecs   = map[string]Codec{}
+)
+
+func init() {
+	RegisterCodec(".json", jsonCodec{})
+	RegisterCodec(".toml", tomlCodec{})
+	RegisterCodec(".xml", xmlCodec{})
+	RegisterCodec(".csv", csvCodec{})
+}
+
+// RegisterCodec registers c as the [Codec] used for files whose extension
+// (including the leading dot, e.g. ".yaml") matches ext, for use by
+// [Config.Marshal] and [Config.Unmarshal].
+//
+// Registering a codec for an extension that already has one replaces it.
+// This lets a caller plug in YAML, HCL, MsgPack, or INI support without
+// confman depending on those packages itself.
+func RegisterCodec(ext string, c Codec) {
+	codecsMu.Lock()
+	defer codecsMu.Unlock()
+
+	codecs[ext] = c
+}
+
+func codecFor(name string) (Codec, error) {
+	ext := filepath.Ext(name)
+
+	codecsMu.RLock()
+	defer codecsMu.RUnlock()
+
+	c, ok := codecs[ext]
+	if !ok {
+		return nil, fmt.Errorf("no codec registered for '%s' files", ext)
+	}
+
+	return c, nil
+}
+
+// Marshal encodes v using the [Codec] registered for name's extension, then writes it into the specified file.
+//
+// If [Config.SetAtomic] is enabled, the write is performed atomically; see [Config.writeAtomic].
+func (c *Config) Marshal(name string, v any) error {
+	codec, err := codecFor(name)
+	if err != nil {
+		return err
+	}
+
+	if c.atomic {
+		return c.writeAtomic(name, func(w io.Writer) error {
+			return codec.Encode(w, v)
+		})
+	}
+
+	w, err := c.OpenWrite(name)
+	if err != nil {
+		return err
+	}
+
+	defer w.Close()
+
+	return codec.Encode(w, v)
+}
+
+// Unmarshal decodes the specified file using the [Codec] registered for its extension, then stores the result into the value pointed to by v.
+//
+// If a schema was registered for name via [Config.RegisterSchema] or [Config.Validate], migrations and validation run before v is populated; see [Config.RegisterSchema].
+func (c *Config) Unmarshal(name string, v any) error {
+	codec, err := codecFor(name)
+	if err != nil {
+		return err
+	}
+
+	return c.decodeWithSchema(name, codec, v)
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Name() string                   { return "json" }
+func (jsonCodec) Encode(w io.Writer, v any) error { return json.NewEncoder(w).Encode(v) }
+func (jsonCodec) Decode(r io.Reader, v any) error { return json.NewDecoder(r).Decode(v) }
+
+type tomlCodec struct{}
+
+func (tomlCodec) Name() string                   { return "toml" }
+func (tomlCodec) Encode(w io.Writer, v any) error { return toml.NewEncoder(w).Encode(v) }
+func (tomlCodec) Decode(r io.Reader, v any) error { _, err := toml.NewDecoder(r).Decode(v); return err }
+
+type xmlCodec struct{}
+
+func (xmlCodec) Name() string                   { return "xml" }
+func (xmlCodec) Encode(w io.Writer, v any) error { return xml.NewEncoder(w).Encode(v) }
+func (xmlCodec) Decode(r io.Reader, v any) error { return xml.NewDecoder(r).Decode(v) }
+
+// csvCodec adapts the CSV reader/writer helpers to the Codec interface. Since
+// CSV has no notion of an arbitrary struct, v must be a *[][]string (for
+// Decode) or a [][]string (for Encode).
+type csvCodec struct{}
+
+func (csvCodec) Name() string { return "csv" }
+
+func (csvCodec) Encode(w io.Writer, v any) error {
+	records, ok := v.([][]string)
+	if !ok {
+		return fmt.Errorf("csv codec: expected [][]string, got %T", v)
+	}
+
+	return csv.NewWriter(w).WriteAll(records)
+}
+
+func (csvCodec) Decode(r io.Reader, v any) error {
+	out, ok := v.(*[][]string)
+	if !ok {
+		return fmt.Errorf("csv codec: expected *[][]string, got %T", v)
+	}
+
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return err
+	}
+
+	*out = records
+
+	return nil
+}
@@ -8,9 +8,11 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 )
 
 type fileDefault struct {
@@ -23,10 +25,28 @@ type Config struct {
 	defaults map[string]fileDefault
 	closers  []io.Closer
 	path     string
+	fs       FS
+	atomic   bool
+
+	watchMu sync.Mutex
+	watches map[string]*fileWatch
+
+	schemas map[string]*schemaInfo
+
+	locksMu sync.Mutex
+	locks   map[string]*os.File
 }
 
-// OpenSpecific creates a Config pointing to the specified path.
+// OpenSpecific creates a Config pointing to the specified path, backed by the real filesystem.
 func OpenSpecific(path string) (*Config, error) {
+	return OpenSpecificFS(osFS{}, path)
+}
+
+// OpenSpecificFS is the same as [OpenSpecific], but reads and writes go through fs instead of the real filesystem.
+//
+// This is primarily useful for testing Config-consuming code with [NewMemFS] instead of touching the real disk,
+// or for layering a writable fs on top of a read-only set of embedded defaults.
+func OpenSpecificFS(fs FS, path string) (*Config, error) {
 	path = filepath.Clean(path)
 
 	if path == "" {
@@ -36,6 +56,7 @@ func OpenSpecific(path string) (*Config, error) {
 	conf := &Config{
 		defaults: map[string]fileDefault{},
 		path:     path,
+		fs:       fs,
 	}
 
 	err := conf.create()
@@ -112,13 +133,25 @@ func (c *Config) Close() []error {
 //
 // This is a very dangerous function, it can lead to unrecoverable data loss.
 func (c *Config) Delete() error {
-	return os.RemoveAll(c.path)
+	return c.fs.RemoveAll(c.path)
 }
 
 func (c *Config) addCloser(closer io.Closer) {
 	c.closers = append(c.closers, closer)
 }
 
+// removeCloser undoes a prior addCloser, for a closer that tore itself down
+// before [Config.Close] did, such as a [fileWatch] whose last subscriber
+// stopped.
+func (c *Config) removeCloser(closer io.Closer) {
+	for i, cl := range c.closers {
+		if cl == closer {
+			c.closers = append(c.closers[:i], c.closers[i+1:]...)
+			return
+		}
+	}
+}
+
 func (c *Config) child(name string) string {
 	return filepath.Join(c.path, name)
 }
@@ -126,7 +159,7 @@ func (c *Config) child(name string) string {
 // Stat returns a [FileInfo] describing the specified file.
 // If there is an error, it will be of type [*PathError].
 func (c *Config) Stat(name string) (os.FileInfo, error) {
-	return os.Stat(c.child(name))
+	return c.fs.Stat(c.child(name))
 }
 
 // Exists checks if specified exists or not.
@@ -148,7 +181,7 @@ func (c *Config) Exists(name string) (bool, error) {
 //
 // This is a very dangerous function, it can lead to unrecoverable data loss.
 func (c *Config) DeleteFile(name string) error {
-	return os.RemoveAll(c.path)
+	return c.fs.RemoveAll(c.child(name))
 }
 
 func (c *Config) create() error {
@@ -158,7 +191,7 @@ func (c *Config) create() error {
 		return nil
 	}
 
-	return os.Mkdir(c.path, os.ModeDir|0o777)
+	return c.fs.Mkdir(c.path, os.ModeDir|0o777)
 }
 
 func (c *Config) verifyExists(name string) error {
@@ -219,8 +252,39 @@ func (c *Config) DefaultString(name string, perm os.FileMode, str string) {
 	c.Default(name, perm, []byte(str))
 }
 
+// DefaultFS sets the default contents of the specified file to the contents of srcName, read out of fsys, if it doesn't exist when calling any Config.Read, Config.Write, or any of their variants.
+//
+// fsys is typically a //go:embed variable, letting a template config shipped alongside the binary be materialized on first launch without holding its contents as a byte slice for the lifetime of the program.
+//
+// This is a convienence function over top of [DefaultFunc]
+func (c *Config) DefaultFS(name string, perm os.FileMode, fsys fs.FS, srcName string) {
+	c.DefaultFunc(name, perm, func(_ *Config, w io.Writer) error {
+		r, err := fsys.Open(srcName)
+		if err != nil {
+			return err
+		}
+
+		defer r.Close()
+
+		_, err = io.Copy(w, r)
+		return err
+	})
+}
+
+// DefaultFile sets the default contents of the specified file to the contents of srcPath on disk, if it doesn't exist when calling any Config.Read, Config.Write, or any of their variants.
+//
+// This is a convienence function over top of [DefaultFS]
+func (c *Config) DefaultFile(name string, perm os.FileMode, srcPath string) {
+	dir, base := filepath.Split(filepath.Clean(srcPath))
+	if dir == "" {
+		dir = "."
+	}
+
+	c.DefaultFS(name, perm, os.DirFS(dir), base)
+}
+
 // OpenRaw is the generalized open call; most users will use [OpenRead],
 // [OpenWrite], or [OpenCreate] instead. It opens the named file with specified flag and file permissions.
 func (c *Config) OpenRaw(name string, flag int, perm os.FileMode) (io.ReadWriteCloser, error) {
-	return os.OpenFile(c.child(name), flag, perm)
+	return c.fs.OpenFile(c.child(name), flag, perm)
 }
@@ -31,6 +31,7 @@ func (c *Config) OpenCreateAuto(name string, perm os.FileMode) (io.ReadWriter, e
 	}
 
 	c.addCloser(w)
+	c.lockAutoOpened(name, w)
 
 	return w, err
 }
@@ -7,7 +7,7 @@ import "os"
 // [os.ModeDir] is masked onto perm internally, and does not need to be specified.
 func (c *Config) Mkdir(name string, perm os.FileMode) (Path, error) {
 	path := c.child(name)
-	return Path(path), os.Mkdir(path, perm|os.ModeDir)
+	return Path(path), c.fs.Mkdir(path, perm|os.ModeDir)
 }
 
 // MkdirAll creates a subdirectory named path,
@@ -21,5 +21,5 @@ func (c *Config) Mkdir(name string, perm os.FileMode) (Path, error) {
 // [os.ModeDir] is masked onto perm internally, and does not need to be specified.
 func (c *Config) MkdirAll(name string, perm os.FileMode) (Path, error) {
 	path := c.child(name)
-	return Path(path), os.MkdirAll(path, perm|os.ModeDir)
+	return Path(path), c.fs.MkdirAll(path, perm|os.ModeDir)
 }
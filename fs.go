@@ -0,0 +1,67 @@
+// Copyright 2025 Nuclear Pasta. All rights reserved.
+// Use of this source code is governed by the MIT license.
+// license that can be found in the LICENSE file.
+
+package confman
+
+import (
+	"io"
+	"os"
+)
+
+// FS abstracts the filesystem operations a Config performs against its
+// directory, allowing the backing store to be swapped out for something
+// other than the real disk.
+//
+// Paths passed to an FS are the Config's directory (the path given to
+// [OpenSpecificFS]) joined with a file name; the FS receives them as they
+// are and applies no root of its own.
+type FS interface {
+	// OpenFile opens the named file with the given flag and permissions,
+	// creating the file if O_CREATE is set.
+	OpenFile(name string, flag int, perm os.FileMode) (io.ReadWriteCloser, error)
+	// Mkdir creates a single directory.
+	Mkdir(name string, perm os.FileMode) error
+	// MkdirAll creates a directory along with any necessary parents.
+	MkdirAll(name string, perm os.FileMode) error
+	// Stat returns a FileInfo describing the named file or directory.
+	Stat(name string) (os.FileInfo, error)
+	// Remove removes the named file or empty directory.
+	Remove(name string) error
+	// RemoveAll removes the named file or directory and any children it contains.
+	RemoveAll(name string) error
+	// Rename renames (moves) oldname to newname, replacing newname if it already exists.
+	Rename(oldname, newname string) error
+}
+
+// osFS is the default [FS] implementation, backed by the real filesystem via
+// the os package.
+type osFS struct{}
+
+func (osFS) OpenFile(name string, flag int, perm os.FileMode) (io.ReadWriteCloser, error) {
+	return os.OpenFile(name, flag, perm)
+}
+
+func (osFS) Mkdir(name string, perm os.FileMode) error {
+	return os.Mkdir(name, perm)
+}
+
+func (osFS) MkdirAll(name string, perm os.FileMode) error {
+	return os.MkdirAll(name, perm)
+}
+
+func (osFS) Stat(name string) (os.FileInfo, error) {
+	return os.Stat(name)
+}
+
+func (osFS) Remove(name string) error {
+	return os.Remove(name)
+}
+
+func (osFS) RemoveAll(name string) error {
+	return os.RemoveAll(name)
+}
+
+func (osFS) Rename(oldname, newname string) error {
+	return os.Rename(oldname, newname)
+}
@@ -0,0 +1,161 @@
+// Copyright 2025 Nuclear Pasta. All rights reserved.
+// Use of this source code is governed by the MIT license.
+// license that can be found in the LICENSE file.
+
+package confman
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// ErrLocked is returned by [Config.TryLock] when the file is already locked
+// by another holder.
+var ErrLocked = errors.New("confman: file is locked")
+
+// Lock acquires an advisory, exclusive OS lock (flock on Unix, LockFileEx on
+// Windows) on the specified file, blocking until it is available. The
+// returned unlock function releases it; callers should always call it,
+// typically via defer.
+//
+// Two processes both calling [Config.WriteJson] (or any of the other write
+// helpers) on the same file without locking can interleave their encoder
+// output and corrupt it. [Config.LockedWrite] and [Config.LockedRead] wrap
+// Lock around [Config.Write] and [Config.Read]; the other write and read
+// helpers do not take the lock themselves.
+//
+// If the file doesn't exist, its default is created first, the same as for
+// [Config.Read]; without a default, an error is returned.
+//
+// Lock requires a Config backed by the real filesystem, i.e. one opened with
+// [OpenSpecific], [OpenHome], or [Open]. For any other FS, including a
+// [MemFS] or a caller's own FS passed to [OpenSpecificFS], Lock returns an
+// error rather than silently locking (or writing to) the real disk
+// underneath it.
+func (c *Config) Lock(name string) (unlock func(), err error) {
+	return c.lock(name, true)
+}
+
+// TryLock is the same as [Config.Lock], but returns [ErrLocked] immediately
+// instead of blocking if the file is already locked.
+func (c *Config) TryLock(name string) (unlock func(), err error) {
+	return c.lock(name, false)
+}
+
+func (c *Config) lock(name string, block bool) (func(), error) {
+	if _, ok := c.fs.(osFS); !ok {
+		return nil, fmt.Errorf("confman: Lock requires a Config backed by the real filesystem, got FS of type %T", c.fs)
+	}
+
+	if err := c.verifyExists(name); err != nil {
+		return nil, err
+	}
+
+	path := c.child(name)
+
+	c.locksMu.Lock()
+	if _, held := c.locks[path]; held {
+		c.locksMu.Unlock()
+		// This Config already holds an OS lock on the file, taken when it
+		// was opened via OpenReadAuto/OpenWriteAuto/OpenCreateAuto (see
+		// lockAutoOpened) or by an earlier, still-outstanding Lock call.
+		// Recognize that ownership instead of taking a second OS-level lock
+		// from a different fd, which would deadlock against ourselves.
+		return func() {}, nil
+	}
+	c.locksMu.Unlock()
+
+	handle, err := c.fs.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o666)
+	if err != nil {
+		return nil, err
+	}
+
+	// The osFS type assertion above guarantees this came from os.OpenFile.
+	f := handle.(*os.File)
+
+	if err := lockFile(f, block); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	c.locksMu.Lock()
+	if c.locks == nil {
+		c.locks = map[string]*os.File{}
+	}
+	c.locks[path] = f
+	c.locksMu.Unlock()
+
+	unlocked := false
+
+	return func() {
+		if unlocked {
+			return
+		}
+
+		unlocked = true
+
+		c.locksMu.Lock()
+		delete(c.locks, path)
+		c.locksMu.Unlock()
+
+		unlockFile(f)
+		f.Close()
+	}, nil
+}
+
+// lockAutoOpened takes an exclusive OS lock on an fd just opened by
+// [Config.OpenReadAuto], [Config.OpenWriteAuto], or [Config.OpenCreateAuto],
+// and records that this Config now owns the lock on name. This makes the
+// long-lived auto-opened fd itself the lock holder, so other processes
+// correctly see the file as locked for as long as it stays open, and a later
+// [Config.Lock] call on the same Config recognizes the file is already
+// locked rather than attempting (and deadlocking on) a second OS-level lock.
+//
+// It is a no-op when handle isn't a real *os.File (e.g. a [MemFS] handle),
+// since advisory OS locks don't apply there.
+func (c *Config) lockAutoOpened(name string, handle any) {
+	f, ok := handle.(*os.File)
+	if !ok {
+		return
+	}
+
+	if err := lockFile(f, true); err != nil {
+		return
+	}
+
+	path := c.child(name)
+
+	c.locksMu.Lock()
+	if c.locks == nil {
+		c.locks = map[string]*os.File{}
+	}
+	c.locks[path] = f
+	c.locksMu.Unlock()
+}
+
+// LockedWrite is the same as [Config.Write], but holds an exclusive lock on
+// the file for the duration of the write.
+func (c *Config) LockedWrite(name string, data []byte) (int, error) {
+	unlock, err := c.Lock(name)
+	if err != nil {
+		return 0, err
+	}
+
+	defer unlock()
+
+	return c.Write(name, data)
+}
+
+// LockedRead is the same as [Config.Read], but holds a lock on the file for
+// the duration of the read.
+func (c *Config) LockedRead(name string) ([]byte, error) {
+	unlock, err := c.Lock(name)
+	if err != nil {
+		return nil, err
+	}
+
+	defer unlock()
+
+	return c.Read(name)
+}
@@ -0,0 +1,96 @@
+// Copyright 2025 Nuclear Pasta. All rights reserved.
+// Use of this source code is governed by the MIT license.
+// license that can be found in the LICENSE file.
+
+package confman
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestLockRejectsNonOSBackedFS(t *testing.T) {
+	c := newMemConfig(t)
+
+	if _, err := c.Create("app.json", []byte("{}"), 0o644); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	if _, err := c.Lock("app.json"); err == nil {
+		t.Fatal("Lock on a MemFS-backed Config returned nil error, want one")
+	}
+}
+
+func TestTryLockFailsWhileAlreadyLockedByAnotherHolder(t *testing.T) {
+	dir := t.TempDir()
+
+	c1, err := OpenSpecific(filepath.Join(dir, "cfg"))
+	if err != nil {
+		t.Fatalf("OpenSpecific (1): %v", err)
+	}
+
+	if _, err := c1.Create("app.json", []byte("{}"), 0o644); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	c2, err := OpenSpecific(filepath.Join(dir, "cfg"))
+	if err != nil {
+		t.Fatalf("OpenSpecific (2): %v", err)
+	}
+
+	unlock1, err := c1.Lock("app.json")
+	if err != nil {
+		t.Fatalf("Lock (1): %v", err)
+	}
+
+	defer unlock1()
+
+	if _, err := c2.TryLock("app.json"); !errors.Is(err, ErrLocked) {
+		t.Fatalf("TryLock (2) while locked by c1 = %v, want ErrLocked", err)
+	}
+
+	unlock1()
+
+	unlock2, err := c2.TryLock("app.json")
+	if err != nil {
+		t.Fatalf("TryLock (2) after c1 unlocked: %v", err)
+	}
+
+	unlock2()
+}
+
+func TestOpenWriteAutoHoldsLockForProcessLifetime(t *testing.T) {
+	dir := t.TempDir()
+
+	c1, err := OpenSpecific(filepath.Join(dir, "cfg"))
+	if err != nil {
+		t.Fatalf("OpenSpecific (1): %v", err)
+	}
+
+	if _, err := c1.Create("log.txt", []byte(""), 0o644); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	if _, err := c1.OpenWriteAuto("log.txt"); err != nil {
+		t.Fatalf("OpenWriteAuto: %v", err)
+	}
+
+	// c1 itself should treat the auto-opened fd as already holding the lock,
+	// rather than deadlocking trying to take a second one.
+	unlock, err := c1.Lock("log.txt")
+	if err != nil {
+		t.Fatalf("Lock on a Config that already auto-opened the file: %v", err)
+	}
+
+	unlock()
+
+	c2, err := OpenSpecific(filepath.Join(dir, "cfg"))
+	if err != nil {
+		t.Fatalf("OpenSpecific (2): %v", err)
+	}
+
+	if _, err := c2.TryLock("log.txt"); !errors.Is(err, ErrLocked) {
+		t.Fatalf("TryLock from a second Config while c1's OpenWriteAuto fd is open = %v, want ErrLocked", err)
+	}
+}
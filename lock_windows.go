@@ -0,0 +1,38 @@
+// Copyright 2025 Nuclear Pasta. All rights reserved.
+// Use of this source code is governed by the MIT license.
+// license that can be found in the LICENSE file.
+
+//go:build windows
+
+package confman
+
+import (
+	"errors"
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+func lockFile(f *os.File, block bool) error {
+	flags := uint32(windows.LOCKFILE_EXCLUSIVE_LOCK)
+	if !block {
+		flags |= windows.LOCKFILE_FAIL_IMMEDIATELY
+	}
+
+	ol := new(windows.Overlapped)
+
+	if err := windows.LockFileEx(windows.Handle(f.Fd()), flags, 0, 1, 0, ol); err != nil {
+		if !block && errors.Is(err, windows.ERROR_LOCK_VIOLATION) {
+			return ErrLocked
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func unlockFile(f *os.File) error {
+	ol := new(windows.Overlapped)
+	return windows.UnlockFileEx(windows.Handle(f.Fd()), 0, 1, 0, ol)
+}
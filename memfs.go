@@ -0,0 +1,255 @@
+// Copyright 2025 Nuclear Pasta. All rights reserved.
+// Use of this source code is governed by the MIT license.
+// license that can be found in the LICENSE file.
+
+package confman
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MemFS is an in-memory [FS] implementation. It is useful for unit testing
+// code that uses a Config without touching the real disk, and as a layer in
+// setups where in-memory edits should shadow a read-only set of embedded
+// defaults.
+//
+// The zero value is not usable; create one with [NewMemFS].
+type MemFS struct {
+	mu    sync.Mutex
+	files map[string]*memFileData
+}
+
+type memFileData struct {
+	data    []byte
+	mode    os.FileMode
+	isDir   bool
+	modTime time.Time
+}
+
+// NewMemFS creates an empty MemFS.
+func NewMemFS() *MemFS {
+	return &MemFS{files: map[string]*memFileData{}}
+}
+
+func memClean(name string) string {
+	return filepath.ToSlash(filepath.Clean(name))
+}
+
+func (m *MemFS) OpenFile(name string, flag int, perm os.FileMode) (io.ReadWriteCloser, error) {
+	name = memClean(name)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	f, ok := m.files[name]
+
+	if !ok {
+		if flag&os.O_CREATE == 0 {
+			return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+		}
+
+		f = &memFileData{mode: perm, modTime: time.Now()}
+		m.files[name] = f
+	} else if flag&os.O_CREATE != 0 && flag&os.O_EXCL != 0 {
+		return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrExist}
+	}
+
+	if flag&os.O_TRUNC != 0 {
+		f.data = nil
+	}
+
+	handle := &memFileHandle{fs: m, name: name}
+
+	if flag&os.O_WRONLY == 0 {
+		handle.reader = bytes.NewReader(append([]byte(nil), f.data...))
+	}
+
+	return handle, nil
+}
+
+// memFileHandle implements io.ReadWriteCloser over a MemFS entry. Reads are
+// served from a snapshot taken at open time; writes are appended and
+// committed back into the MemFS on Close, mirroring the truncate-then-append
+// semantics the Config write helpers rely on.
+type memFileHandle struct {
+	fs     *MemFS
+	name   string
+	reader *bytes.Reader
+	buf    bytes.Buffer
+}
+
+func (h *memFileHandle) Read(p []byte) (int, error) {
+	if h.reader == nil {
+		return 0, &os.PathError{Op: "read", Path: h.name, Err: os.ErrInvalid}
+	}
+
+	return h.reader.Read(p)
+}
+
+func (h *memFileHandle) Write(p []byte) (int, error) {
+	return h.buf.Write(p)
+}
+
+func (h *memFileHandle) Close() error {
+	if h.buf.Len() == 0 {
+		return nil
+	}
+
+	h.fs.mu.Lock()
+	defer h.fs.mu.Unlock()
+
+	f, ok := h.fs.files[h.name]
+	if !ok {
+		return &os.PathError{Op: "close", Path: h.name, Err: os.ErrNotExist}
+	}
+
+	f.data = append(f.data, h.buf.Bytes()...)
+	f.modTime = time.Now()
+
+	return nil
+}
+
+func (m *MemFS) Mkdir(name string, perm os.FileMode) error {
+	name = memClean(name)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.files[name]; ok {
+		return &os.PathError{Op: "mkdir", Path: name, Err: os.ErrExist}
+	}
+
+	m.files[name] = &memFileData{mode: perm, isDir: true, modTime: time.Now()}
+
+	return nil
+}
+
+func (m *MemFS) MkdirAll(name string, perm os.FileMode) error {
+	name = memClean(name)
+
+	prefix := ""
+	if strings.HasPrefix(name, "/") {
+		prefix = "/"
+	}
+
+	parts := strings.Split(strings.Trim(name, "/"), "/")
+	path := ""
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, part := range parts {
+		if part == "" {
+			continue
+		}
+
+		if path == "" {
+			path = prefix + part
+		} else {
+			path = path + "/" + part
+		}
+
+		if f, ok := m.files[path]; ok {
+			if !f.isDir {
+				return &os.PathError{Op: "mkdir", Path: path, Err: os.ErrExist}
+			}
+
+			continue
+		}
+
+		m.files[path] = &memFileData{mode: perm, isDir: true, modTime: time.Now()}
+	}
+
+	return nil
+}
+
+func (m *MemFS) Stat(name string) (os.FileInfo, error) {
+	name = memClean(name)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	f, ok := m.files[name]
+	if !ok {
+		return nil, &os.PathError{Op: "stat", Path: name, Err: os.ErrNotExist}
+	}
+
+	return &memFileInfo{name: filepath.Base(name), data: f}, nil
+}
+
+func (m *MemFS) Remove(name string) error {
+	name = memClean(name)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.files[name]; !ok {
+		return &os.PathError{Op: "remove", Path: name, Err: os.ErrNotExist}
+	}
+
+	delete(m.files, name)
+
+	return nil
+}
+
+func (m *MemFS) RemoveAll(name string) error {
+	name = memClean(name)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	prefix := name + "/"
+
+	paths := make([]string, 0, 1)
+	for path := range m.files {
+		if path == name || strings.HasPrefix(path, prefix) {
+			paths = append(paths, path)
+		}
+	}
+
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		delete(m.files, path)
+	}
+
+	return nil
+}
+
+func (m *MemFS) Rename(oldname, newname string) error {
+	oldname = memClean(oldname)
+	newname = memClean(newname)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	f, ok := m.files[oldname]
+	if !ok {
+		return &os.PathError{Op: "rename", Path: oldname, Err: os.ErrNotExist}
+	}
+
+	m.files[newname] = f
+	delete(m.files, oldname)
+
+	return nil
+}
+
+// memFileInfo implements [os.FileInfo] for a MemFS entry.
+type memFileInfo struct {
+	name string
+	data *memFileData
+}
+
+func (i *memFileInfo) Name() string       { return i.name }
+func (i *memFileInfo) Size() int64        { return int64(len(i.data.data)) }
+func (i *memFileInfo) Mode() os.FileMode  { return i.data.mode }
+func (i *memFileInfo) ModTime() time.Time { return i.data.modTime }
+func (i *memFileInfo) IsDir() bool        { return i.data.isDir }
+func (i *memFileInfo) Sys() any           { return nil }
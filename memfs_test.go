@@ -0,0 +1,88 @@
+// Copyright 2025 Nuclear Pasta. All rights reserved.
+// Use of this source code is governed by the MIT license.
+// license that can be found in the LICENSE file.
+
+package confman
+
+import "testing"
+
+func newMemConfig(t *testing.T) *Config {
+	t.Helper()
+
+	c, err := OpenSpecificFS(NewMemFS(), "/cfg")
+	if err != nil {
+		t.Fatalf("OpenSpecificFS: %v", err)
+	}
+
+	return c
+}
+
+func TestMemFSWriteReadRoundTrip(t *testing.T) {
+	c := newMemConfig(t)
+
+	if _, err := c.Create("hello.txt", []byte("hi"), 0o644); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	got, err := c.ReadString("hello.txt")
+	if err != nil {
+		t.Fatalf("ReadString: %v", err)
+	}
+
+	if got != "hi" {
+		t.Fatalf("ReadString = %q, want %q", got, "hi")
+	}
+
+	if _, err := c.Write("hello.txt", []byte("bye")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	got, err = c.ReadString("hello.txt")
+	if err != nil {
+		t.Fatalf("ReadString after Write: %v", err)
+	}
+
+	if got != "bye" {
+		t.Fatalf("ReadString after Write = %q, want %q", got, "bye")
+	}
+}
+
+func TestMemFSExistsAndDeleteFile(t *testing.T) {
+	c := newMemConfig(t)
+
+	if exists, err := c.Exists("missing.txt"); err != nil || exists {
+		t.Fatalf("Exists(missing.txt) = %v, %v, want false, nil", exists, err)
+	}
+
+	if _, err := c.Create("present.txt", []byte("x"), 0o644); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	if exists, err := c.Exists("present.txt"); err != nil || !exists {
+		t.Fatalf("Exists(present.txt) = %v, %v, want true, nil", exists, err)
+	}
+
+	if err := c.DeleteFile("present.txt"); err != nil {
+		t.Fatalf("DeleteFile: %v", err)
+	}
+
+	if exists, err := c.Exists("present.txt"); err != nil || exists {
+		t.Fatalf("Exists(present.txt) after DeleteFile = %v, %v, want false, nil", exists, err)
+	}
+
+	if exists, err := c.Exists(""); err != nil || !exists {
+		t.Fatalf("config directory exists after DeleteFile = %v, %v, want true, nil", exists, err)
+	}
+}
+
+func TestMemFSMkdirAll(t *testing.T) {
+	c := newMemConfig(t)
+
+	if _, err := c.MkdirAll("a/b/c", 0o755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+
+	if exists, err := c.Exists("a/b/c"); err != nil || !exists {
+		t.Fatalf("Exists(a/b/c) = %v, %v, want true, nil", exists, err)
+	}
+}
@@ -6,12 +6,9 @@ package confman
 
 import (
 	"encoding/csv"
-	"encoding/json"
 	"encoding/xml"
 	"io"
 	"os"
-
-	"github.com/BurntSushi/toml"
 )
 
 // OpenRead opens the specified file for reading.
@@ -37,6 +34,7 @@ func (c *Config) OpenReadAuto(name string) (io.Reader, error) {
 	}
 
 	c.addCloser(r)
+	c.lockAutoOpened(name, r)
 
 	return r, err
 }
@@ -64,28 +62,17 @@ func (c *Config) ReadString(name string) (string, error) {
 }
 
 // ReadJson parses the specified file as JSON, then stores the result into the value pointed to by v.
+//
+// If a schema was registered for name via [Config.RegisterSchema] or [Config.Validate], migrations and validation run before v is populated; see [Config.RegisterSchema].
 func (c *Config) ReadJson(name string, v any) error {
-	r, err := c.OpenRead(name)
-	if err != nil {
-		return err
-	}
-
-	defer r.Close()
-
-	return json.NewDecoder(r).Decode(v)
+	return c.decodeWithSchema(name, jsonCodec{}, v)
 }
 
 // ReadJson parses the specified file as TOMl, then stores the result into the value pointed to by v.
+//
+// If a schema was registered for name via [Config.RegisterSchema] or [Config.Validate], migrations and validation run before v is populated; see [Config.RegisterSchema].
 func (c *Config) ReadToml(name string, v any) error {
-	r, err := c.OpenRead(name)
-	if err != nil {
-		return err
-	}
-
-	defer r.Close()
-
-	_, err = toml.NewDecoder(r).Decode(v)
-	return err
+	return c.decodeWithSchema(name, tomlCodec{}, v)
 }
 
 // ReadJson parses the specified file as a CSV, then returns the records as a slice of slices of strings
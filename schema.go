@@ -0,0 +1,236 @@
+// Copyright 2025 Nuclear Pasta. All rights reserved.
+// Use of this source code is governed by the MIT license.
+// license that can be found in the LICENSE file.
+
+package confman
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// A Migration upgrades a decoded config document from schema version From to
+// version To.
+type Migration struct {
+	From, To int
+	Migrate  func(raw map[string]any) (map[string]any, error)
+}
+
+// A Validator checks a decoded config document, typically against a JSON
+// Schema or some other user-defined set of rules.
+type Validator interface {
+	Validate(raw map[string]any) error
+}
+
+// schemaInfo holds the migrations and validator registered for one file.
+type schemaInfo struct {
+	versions  []Migration
+	validator Validator
+}
+
+// RegisterSchema registers the chain of migrations used to bring name's file
+// up to date whenever it is read through [Config.ReadJson], [Config.ReadToml],
+// or [Config.Unmarshal].
+//
+// On read, confman inspects the document's "schema_version" field (treated
+// as 0 if absent), applies migrations whose From matches the current version
+// in order, and repeats until no further migration applies. The file is then
+// rewritten with the resulting schema_version, through [Config.writeAtomic]
+// if [Config.SetAtomic] is enabled, so later reads don't re-run migrations
+// that already happened.
+func (c *Config) RegisterSchema(name string, versions []Migration) {
+	c.schemaFor(name).versions = versions
+}
+
+// Validate registers validator as the gate for reads of the specified file
+// through [Config.ReadJson], [Config.ReadToml], or [Config.Unmarshal], then
+// immediately runs it against the file's current contents if the file
+// exists.
+//
+// validator is called with the decoded document after any schema migrations
+// have run, the same as on a real read; an error fails the read.
+func (c *Config) Validate(name string, validator Validator) error {
+	s := c.schemaFor(name)
+	s.validator = validator
+
+	exists, err := c.Exists(name)
+	if err != nil {
+		return err
+	} else if !exists {
+		return nil
+	}
+
+	codec, err := codecFor(name)
+	if err != nil {
+		return err
+	}
+
+	r, err := c.OpenRead(name)
+	if err != nil {
+		return err
+	}
+
+	raw := map[string]any{}
+	err = codec.Decode(r, &raw)
+	r.Close()
+
+	if err != nil {
+		return err
+	}
+
+	migrated, _, err := s.migrate(raw)
+	if err != nil {
+		return err
+	}
+
+	return validator.Validate(migrated)
+}
+
+func (c *Config) schemaFor(name string) *schemaInfo {
+	if c.schemas == nil {
+		c.schemas = map[string]*schemaInfo{}
+	}
+
+	s, ok := c.schemas[name]
+	if !ok {
+		s = &schemaInfo{}
+		c.schemas[name] = s
+	}
+
+	return s
+}
+
+// decodeWithSchema decodes the specified file with codec into v, applying
+// any migrations and validator registered for name via [Config.RegisterSchema]
+// and [Config.Validate] along the way.
+func (c *Config) decodeWithSchema(name string, codec Codec, v any) error {
+	s := c.schemas[name]
+	if s == nil || (len(s.versions) == 0 && s.validator == nil) {
+		r, err := c.OpenRead(name)
+		if err != nil {
+			return err
+		}
+
+		defer r.Close()
+
+		return codec.Decode(r, v)
+	}
+
+	r, err := c.OpenRead(name)
+	if err != nil {
+		return err
+	}
+
+	raw := map[string]any{}
+	err = codec.Decode(r, &raw)
+	r.Close()
+
+	if err != nil {
+		return err
+	}
+
+	startVersion := schemaVersionOf(raw)
+
+	migrated, version, err := s.migrate(raw)
+	if err != nil {
+		return err
+	}
+
+	if s.validator != nil {
+		if err := s.validator.Validate(migrated); err != nil {
+			return err
+		}
+	}
+
+	if err := reencode(codec, migrated, v); err != nil {
+		return err
+	}
+
+	if version == startVersion {
+		return nil
+	}
+
+	return c.rewriteRaw(name, codec, migrated)
+}
+
+// migrate applies every applicable migration to raw in order, returning the
+// migrated document and its resulting schema version. It does not read or
+// write any shared state, so it is safe to call concurrently for the same
+// schemaInfo — e.g. from a [Config.Watch] reload racing an [Config.Unmarshal]
+// call on the same file.
+func (s *schemaInfo) migrate(raw map[string]any) (map[string]any, int, error) {
+	version := schemaVersionOf(raw)
+
+	for {
+		applied := false
+
+		for _, m := range s.versions {
+			if m.From != version {
+				continue
+			}
+
+			next, err := m.Migrate(raw)
+			if err != nil {
+				return nil, 0, fmt.Errorf("migrating schema_version %d to %d: %w", m.From, m.To, err)
+			}
+
+			raw = next
+			version = m.To
+			applied = true
+
+			break
+		}
+
+		if !applied {
+			break
+		}
+	}
+
+	raw["schema_version"] = version
+
+	return raw, version, nil
+}
+
+func schemaVersionOf(raw map[string]any) int {
+	switch n := raw["schema_version"].(type) {
+	case int:
+		return n
+	case int64:
+		return int(n)
+	case float64:
+		return int(n)
+	default:
+		return 0
+	}
+}
+
+// reencode round-trips raw through codec's own Encode/Decode so it lands in
+// v with the same format-specific type conversions (e.g. TOML's int64s) that
+// a direct decode would have produced.
+func reencode(codec Codec, raw map[string]any, v any) error {
+	var buf bytes.Buffer
+
+	if err := codec.Encode(&buf, raw); err != nil {
+		return err
+	}
+
+	return codec.Decode(&buf, v)
+}
+
+func (c *Config) rewriteRaw(name string, codec Codec, raw map[string]any) error {
+	if c.atomic {
+		return c.writeAtomic(name, func(w io.Writer) error {
+			return codec.Encode(w, raw)
+		})
+	}
+
+	w, err := c.OpenWrite(name)
+	if err != nil {
+		return err
+	}
+
+	defer w.Close()
+
+	return codec.Encode(w, raw)
+}
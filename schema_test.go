@@ -0,0 +1,104 @@
+// Copyright 2025 Nuclear Pasta. All rights reserved.
+// Use of this source code is governed by the MIT license.
+// license that can be found in the LICENSE file.
+
+package confman
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type appConfig struct {
+	SchemaVersion int    `json:"schema_version"`
+	Name          string `json:"name"`
+}
+
+func TestRegisterSchemaChainsMigrations(t *testing.T) {
+	c := newMemConfig(t)
+
+	// v0 docs stored "username"; v1 renamed it to "name"; v2 adds nothing
+	// new but bumps the version, exercising a chain of more than one hop.
+	c.RegisterSchema("app.json", []Migration{
+		{
+			From: 0,
+			To:   1,
+			Migrate: func(raw map[string]any) (map[string]any, error) {
+				raw["name"] = raw["username"]
+				delete(raw, "username")
+				return raw, nil
+			},
+		},
+		{
+			From: 1,
+			To:   2,
+			Migrate: func(raw map[string]any) (map[string]any, error) {
+				return raw, nil
+			},
+		},
+	})
+
+	if _, err := c.Create("app.json", []byte(`{"username":"ada"}`), 0o644); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	var cfg appConfig
+	if err := c.ReadJson("app.json", &cfg); err != nil {
+		t.Fatalf("ReadJson: %v", err)
+	}
+
+	if cfg.Name != "ada" || cfg.SchemaVersion != 2 {
+		t.Fatalf("ReadJson decoded %+v, want Name=ada SchemaVersion=2", cfg)
+	}
+
+	// The file on disk should have been rewritten with the migrated
+	// document, so a second read doesn't re-run any migrations.
+	raw, err := c.ReadString("app.json")
+	if err != nil {
+		t.Fatalf("ReadString: %v", err)
+	}
+
+	if !containsAll(raw, `"schema_version":2`, `"name":"ada"`) {
+		t.Fatalf("rewritten app.json = %q, want migrated fields present", raw)
+	}
+}
+
+func TestValidateRejectsBadDocument(t *testing.T) {
+	c := newMemConfig(t)
+
+	if _, err := c.Create("app.json", []byte(`{"name":""}`), 0o644); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	validator := validatorFunc(func(raw map[string]any) error {
+		if raw["name"] == "" {
+			return fmt.Errorf("name must not be empty")
+		}
+
+		return nil
+	})
+
+	if err := c.Validate("app.json", validator); err == nil {
+		t.Fatal("Validate on an empty name returned nil error, want one")
+	}
+
+	var cfg appConfig
+	if err := c.ReadJson("app.json", &cfg); err == nil {
+		t.Fatal("ReadJson with a failing validator returned nil error, want one")
+	}
+}
+
+type validatorFunc func(raw map[string]any) error
+
+func (f validatorFunc) Validate(raw map[string]any) error { return f(raw) }
+
+func containsAll(s string, substrs ...string) bool {
+	for _, sub := range substrs {
+		if !strings.Contains(s, sub) {
+			return false
+		}
+	}
+
+	return true
+}
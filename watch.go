@@ -0,0 +1,202 @@
+// Copyright 2025 Nuclear Pasta. All rights reserved.
+// Use of this source code is governed by the MIT license.
+// license that can be found in the LICENSE file.
+
+package confman
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDebounce is how long [Config.Watch] waits after the last filesystem
+// event before reloading, so that a burst of saves from an editor (write,
+// then chmod, then rename-over) only triggers one reload.
+const watchDebounce = 100 * time.Millisecond
+
+// Watch watches the specified file for changes. Whenever the file is
+// modified, it is decoded into v using the [Codec] registered for its
+// extension and onChange is called with the result of that decode (nil on
+// success). Watch itself does not decode the file; v is left as it is until
+// the first change.
+//
+// Multiple calls to Watch for the same file share one underlying
+// [fsnotify.Watcher]. The returned stop function cancels this subscription;
+// once the last subscriber on a file stops, its underlying watcher is closed
+// and torn down too. [Config.Close] tears down every watch still active.
+//
+// Watch requires a Config backed by the real filesystem, i.e. one opened with
+// [OpenSpecific], [OpenHome], or [Open]. For any other FS, including a
+// [MemFS] or a caller's own FS passed to [OpenSpecificFS], Watch returns an
+// error rather than watching the real disk underneath it.
+func (c *Config) Watch(name string, v any, onChange func(error)) (stop func(), err error) {
+	if _, ok := c.fs.(osFS); !ok {
+		return nil, fmt.Errorf("confman: Watch requires a Config backed by the real filesystem, got FS of type %T", c.fs)
+	}
+
+	codec, err := codecFor(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.verifyExists(name); err != nil {
+		return nil, err
+	}
+
+	c.watchMu.Lock()
+	defer c.watchMu.Unlock()
+
+	if c.watches == nil {
+		c.watches = map[string]*fileWatch{}
+	}
+
+	path := c.child(name)
+
+	w, ok := c.watches[path]
+	if !ok {
+		watcher, err := fsnotify.NewWatcher()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := watcher.Add(path); err != nil {
+			watcher.Close()
+			return nil, err
+		}
+
+		w = &fileWatch{
+			conf:        c,
+			name:        name,
+			codec:       codec,
+			watcher:     watcher,
+			subscribers: map[*subscriber]struct{}{},
+		}
+
+		c.watches[path] = w
+		c.addCloser(w)
+
+		go w.run()
+	}
+
+	sub := &subscriber{v: v, onChange: onChange}
+	w.addSubscriber(sub)
+
+	stop = func() {
+		if w.removeSubscriber(sub) > 0 {
+			return
+		}
+
+		c.watchMu.Lock()
+		if c.watches[path] == w {
+			delete(c.watches, path)
+		}
+		c.watchMu.Unlock()
+
+		c.removeCloser(w)
+		w.Close()
+	}
+
+	return stop, nil
+}
+
+// subscriber is one [Config.Watch] registration on a [fileWatch].
+type subscriber struct {
+	v        any
+	onChange func(error)
+}
+
+// fileWatch is the shared fsnotify watch backing every [Config.Watch]
+// subscription on a single file.
+type fileWatch struct {
+	conf    *Config
+	name    string
+	codec   Codec
+	watcher *fsnotify.Watcher
+
+	mu          sync.Mutex
+	subscribers map[*subscriber]struct{}
+	timer       *time.Timer
+}
+
+func (w *fileWatch) addSubscriber(s *subscriber) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.subscribers[s] = struct{}{}
+}
+
+// removeSubscriber removes s and returns how many subscribers remain.
+func (w *fileWatch) removeSubscriber(s *subscriber) int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	delete(w.subscribers, s)
+
+	return len(w.subscribers)
+}
+
+func (w *fileWatch) run() {
+	for {
+		select {
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+
+			if event.Op&(fsnotify.Write|fsnotify.Create) != 0 {
+				w.scheduleReload()
+			}
+		case _, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+		}
+	}
+}
+
+func (w *fileWatch) scheduleReload() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+
+	w.timer = time.AfterFunc(watchDebounce, w.reload)
+}
+
+func (w *fileWatch) reload() {
+	w.mu.Lock()
+	subs := make([]*subscriber, 0, len(w.subscribers))
+	for s := range w.subscribers {
+		subs = append(subs, s)
+	}
+	w.mu.Unlock()
+
+	for _, s := range subs {
+		s.onChange(w.decodeInto(s.v))
+	}
+}
+
+// decodeInto re-reads the watched file into v, applying any schema
+// migrations and validation registered for it via [Config.RegisterSchema] or
+// [Config.Validate] the same way [Config.ReadJson], [Config.ReadToml], and
+// [Config.Unmarshal] do.
+func (w *fileWatch) decodeInto(v any) error {
+	return w.conf.decodeWithSchema(w.name, w.codec, v)
+}
+
+// Close stops this watch's fsnotify watcher. It is invoked for every active
+// watch when the owning [Config] is closed via [Config.Close].
+func (w *fileWatch) Close() error {
+	w.mu.Lock()
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+	w.mu.Unlock()
+
+	return w.watcher.Close()
+}
@@ -0,0 +1,154 @@
+// Copyright 2025 Nuclear Pasta. All rights reserved.
+// Use of this source code is governed by the MIT license.
+// license that can be found in the LICENSE file.
+
+package confman
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newOSConfig(t *testing.T) *Config {
+	t.Helper()
+
+	c, err := OpenSpecific(filepath.Join(t.TempDir(), "cfg"))
+	if err != nil {
+		t.Fatalf("OpenSpecific: %v", err)
+	}
+
+	return c
+}
+
+func TestWatchRejectsNonOSBackedFS(t *testing.T) {
+	c := newMemConfig(t)
+
+	if _, err := c.Create("app.json", []byte(`{"name":"a"}`), 0o644); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	var cfg appConfig
+	if _, err := c.Watch("app.json", &cfg, func(error) {}); err == nil {
+		t.Fatal("Watch on a MemFS-backed Config returned nil error, want one")
+	}
+}
+
+func TestWatchReloadsOnChange(t *testing.T) {
+	c := newOSConfig(t)
+
+	if _, err := c.Create("app.json", []byte(`{"name":"a"}`), 0o644); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	var cfg appConfig
+	changed := make(chan error, 1)
+
+	stop, err := c.Watch("app.json", &cfg, func(err error) { changed <- err })
+	if err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+
+	defer stop()
+
+	if err := os.WriteFile(filepath.Join(c.path, "app.json"), []byte(`{"name":"b"}`), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	select {
+	case err := <-changed:
+		if err != nil {
+			t.Fatalf("onChange called with error: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("onChange was never called after the watched file changed")
+	}
+
+	if cfg.Name != "b" {
+		t.Fatalf("cfg.Name = %q after reload, want %q", cfg.Name, "b")
+	}
+}
+
+func TestWatchStopTearsDownLastSubscriber(t *testing.T) {
+	c := newOSConfig(t)
+
+	if _, err := c.Create("app.json", []byte(`{"name":"a"}`), 0o644); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	var cfg appConfig
+
+	stop, err := c.Watch("app.json", &cfg, func(error) {})
+	if err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+
+	path := c.child("app.json")
+
+	c.watchMu.Lock()
+	_, watching := c.watches[path]
+	c.watchMu.Unlock()
+
+	if !watching {
+		t.Fatal("Watch did not register a fileWatch for app.json")
+	}
+
+	stop()
+
+	c.watchMu.Lock()
+	_, stillWatching := c.watches[path]
+	c.watchMu.Unlock()
+
+	if stillWatching {
+		t.Fatal("stop() did not remove the fileWatch once its last subscriber stopped")
+	}
+
+	for _, closer := range c.closers {
+		if closer == nil {
+			continue
+		}
+
+		if _, ok := closer.(*fileWatch); ok {
+			t.Fatal("stop() did not remove the torn-down fileWatch from Config.closers")
+		}
+	}
+}
+
+func TestWatchSharesOneWatcherAcrossSubscribers(t *testing.T) {
+	c := newOSConfig(t)
+
+	if _, err := c.Create("app.json", []byte(`{"name":"a"}`), 0o644); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	var cfg1, cfg2 appConfig
+
+	stop1, err := c.Watch("app.json", &cfg1, func(error) {})
+	if err != nil {
+		t.Fatalf("Watch (1): %v", err)
+	}
+
+	defer stop1()
+
+	stop2, err := c.Watch("app.json", &cfg2, func(error) {})
+	if err != nil {
+		t.Fatalf("Watch (2): %v", err)
+	}
+
+	path := c.child("app.json")
+
+	c.watchMu.Lock()
+	w1 := c.watches[path]
+	c.watchMu.Unlock()
+
+	stop2()
+
+	c.watchMu.Lock()
+	w2, stillWatching := c.watches[path]
+	c.watchMu.Unlock()
+
+	if !stillWatching || w1 != w2 {
+		t.Fatal("stopping one of two subscribers should not tear down the shared watch")
+	}
+}
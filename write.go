@@ -37,12 +37,26 @@ func (c *Config) OpenWriteAuto(name string) (io.Writer, error) {
 	}
 
 	c.addCloser(w)
+	c.lockAutoOpened(name, w)
 
 	return w, err
 }
 
 // Write writes the entirety of data into the specified file, truncating it.
+//
+// If [Config.SetAtomic] is enabled, the write is performed atomically; see [Config.writeAtomic].
 func (c *Config) Write(name string, data []byte) (int, error) {
+	if c.atomic {
+		n := 0
+		err := c.writeAtomic(name, func(w io.Writer) error {
+			var err error
+			n, err = w.Write(data)
+			return err
+		})
+
+		return n, err
+	}
+
 	w, err := c.OpenWrite(name)
 	if err != nil {
 		return 0, err
@@ -59,7 +73,15 @@ func (c *Config) WriteString(name, str string) (int, error) {
 }
 
 // WriteJson serializes v as JSON and writes it into the specified file.
+//
+// If [Config.SetAtomic] is enabled, the write is performed atomically; see [Config.writeAtomic].
 func (c *Config) WriteJson(name string, v any) error {
+	if c.atomic {
+		return c.writeAtomic(name, func(w io.Writer) error {
+			return json.NewEncoder(w).Encode(v)
+		})
+	}
+
 	w, err := c.OpenWrite(name)
 	if err != nil {
 		return err
@@ -71,7 +93,15 @@ func (c *Config) WriteJson(name string, v any) error {
 }
 
 // WriteJson serializes v as TOML and writes it into the specified file.
+//
+// If [Config.SetAtomic] is enabled, the write is performed atomically; see [Config.writeAtomic].
 func (c *Config) WriteToml(name string, v any) error {
+	if c.atomic {
+		return c.writeAtomic(name, func(w io.Writer) error {
+			return toml.NewEncoder(w).Encode(v)
+		})
+	}
+
 	w, err := c.OpenWrite(name)
 	if err != nil {
 		return err
@@ -83,7 +113,15 @@ func (c *Config) WriteToml(name string, v any) error {
 }
 
 // WriteJson serializes records as a CSV and writes it into the specified file.
+//
+// If [Config.SetAtomic] is enabled, the write is performed atomically; see [Config.writeAtomic].
 func (c *Config) WriteCsv(name string, records [][]string) error {
+	if c.atomic {
+		return c.writeAtomic(name, func(w io.Writer) error {
+			return csv.NewWriter(w).WriteAll(records)
+		})
+	}
+
 	w, err := c.OpenWrite(name)
 	if err != nil {
 		return err
@@ -95,7 +133,15 @@ func (c *Config) WriteCsv(name string, records [][]string) error {
 }
 
 // WriteJson serializes v as XML and writes it into the specified file.
+//
+// If [Config.SetAtomic] is enabled, the write is performed atomically; see [Config.writeAtomic].
 func (c *Config) WriteXml(name string, v any) error {
+	if c.atomic {
+		return c.writeAtomic(name, func(w io.Writer) error {
+			return xml.NewEncoder(w).Encode(v)
+		})
+	}
+
 	w, err := c.OpenWrite(name)
 	if err != nil {
 		return err